@@ -0,0 +1,91 @@
+package gawk
+
+import "github.com/jwalton/gawk/pkg/ansistyles"
+
+// WithRGB returns a new Builder that styles the foreground using a 24-bit
+// RGB color. On a terminal that can't display truecolor, the color is
+// transparently downgraded to the nearest xterm-256 or basic ANSI-16 color,
+// based on the Builder's configured ColorLevel.
+func (builder *Builder) WithRGB(r int, g int, b int) *Builder {
+	return createDynamicBuilder(builder, func(level ColorLevel) (string, string) {
+		return rgbEscapes(level, r, g, b, false)
+	})
+}
+
+// WithBgRGB is like WithRGB, but styles the background.
+func (builder *Builder) WithBgRGB(r int, g int, b int) *Builder {
+	return createDynamicBuilder(builder, func(level ColorLevel) (string, string) {
+		return rgbEscapes(level, r, g, b, true)
+	})
+}
+
+// WithHex is like WithRGB, but takes a "#RRGGBB" (or "#RGB") string. If hex
+// can't be parsed, it is treated as a no-op.
+func (builder *Builder) WithHex(hex string) *Builder {
+	r, g, b, err := ansistyles.HexToRGB(hex)
+	if err != nil {
+		return builder
+	}
+	return builder.WithRGB(r, g, b)
+}
+
+// WithBgHex is like WithHex, but styles the background.
+func (builder *Builder) WithBgHex(hex string) *Builder {
+	r, g, b, err := ansistyles.HexToRGB(hex)
+	if err != nil {
+		return builder
+	}
+	return builder.WithBgRGB(r, g, b)
+}
+
+// WithAnsi256 returns a new Builder that styles the foreground using an
+// xterm-256 color (0-255), downgrading to the nearest basic ANSI-16 color on
+// terminals that don't support 256 colors.
+func (builder *Builder) WithAnsi256(code int) *Builder {
+	return createDynamicBuilder(builder, func(level ColorLevel) (string, string) {
+		return ansi256Escapes(level, code, false)
+	})
+}
+
+// WithBgAnsi256 is like WithAnsi256, but styles the background.
+func (builder *Builder) WithBgAnsi256(code int) *Builder {
+	return createDynamicBuilder(builder, func(level ColorLevel) (string, string) {
+		return ansi256Escapes(level, code, true)
+	})
+}
+
+func rgbEscapes(level ColorLevel, r int, g int, b int, background bool) (string, string) {
+	switch {
+	case level >= LevelAnsi16m:
+		if background {
+			return ansistyles.BgTrueColor(r, g, b), "\u001b[49m"
+		}
+		return ansistyles.FgTrueColor(r, g, b), "\u001b[39m"
+	case level >= LevelAnsi256:
+		return ansi256Escapes(level, ansistyles.RGBToAnsi256(r, g, b), background)
+	case level >= LevelBasic:
+		return ansi16Escapes(ansistyles.RGBToAnsi16(r, g, b), background)
+	default:
+		return "", ""
+	}
+}
+
+func ansi256Escapes(level ColorLevel, code int, background bool) (string, string) {
+	if level >= LevelAnsi256 {
+		if background {
+			return ansistyles.BgAnsi256(code), "\u001b[49m"
+		}
+		return ansistyles.FgAnsi256(code), "\u001b[39m"
+	}
+	if level >= LevelBasic {
+		return ansi16Escapes(ansistyles.Ansi256ToAnsi16(code), background)
+	}
+	return "", ""
+}
+
+func ansi16Escapes(code int, background bool) (string, string) {
+	if background {
+		return ansistyles.BgAnsi16(code), "\u001b[49m"
+	}
+	return ansistyles.FgAnsi16(code), "\u001b[39m"
+}
@@ -0,0 +1,80 @@
+package gawk
+
+import (
+	"testing"
+
+	"github.com/jwalton/gawk/pkg/ansistyles"
+)
+
+func TestWithRGBDowngradesByColorLevel(t *testing.T) {
+	r, g, b := 10, 20, 30
+
+	cases := []struct {
+		level    ColorLevel
+		wantOpen string
+	}{
+		{LevelAnsi16m, ansistyles.FgTrueColor(r, g, b)},
+		{LevelAnsi256, ansistyles.FgAnsi256(ansistyles.RGBToAnsi256(r, g, b))},
+		{LevelBasic, ansistyles.FgAnsi16(ansistyles.RGBToAnsi16(r, g, b))},
+	}
+
+	for _, c := range cases {
+		builder := New(ForceLevel(c.level)).WithRGB(r, g, b)
+		got := builder.applyStyle("x")
+		if want := c.wantOpen + "x\u001b[39m"; got != want {
+			t.Errorf("WithRGB at %v = %q, want %q", c.level, got, want)
+		}
+	}
+}
+
+func TestWithHexDowngradesByColorLevel(t *testing.T) {
+	hex := "#0A141E"
+	r, g, b, err := ansistyles.HexToRGB(hex)
+	if err != nil {
+		t.Fatalf("HexToRGB(%q) returned unexpected error: %v", hex, err)
+	}
+
+	basic := New(ForceLevel(LevelBasic)).WithHex(hex).applyStyle("x")
+	ansi256 := New(ForceLevel(LevelAnsi256)).WithHex(hex).applyStyle("x")
+	truecolor := New(ForceLevel(LevelAnsi16m)).WithHex(hex).applyStyle("x")
+
+	if basic == ansi256 || ansi256 == truecolor || basic == truecolor {
+		t.Errorf("WithHex(%q) rendered the same at different ColorLevels: basic=%q ansi256=%q truecolor=%q", hex, basic, ansi256, truecolor)
+	}
+	if want := ansistyles.FgTrueColor(r, g, b) + "x\u001b[39m"; truecolor != want {
+		t.Errorf("WithHex at LevelAnsi16m = %q, want %q", truecolor, want)
+	}
+}
+
+func TestWithAnsi256DowngradesByColorLevel(t *testing.T) {
+	code := 200
+
+	ansi256 := New(ForceLevel(LevelAnsi256)).WithAnsi256(code).applyStyle("x")
+	wantAnsi256 := ansistyles.FgAnsi256(code) + "x\u001b[39m"
+	if ansi256 != wantAnsi256 {
+		t.Errorf("WithAnsi256 at LevelAnsi256 = %q, want %q", ansi256, wantAnsi256)
+	}
+
+	basic := New(ForceLevel(LevelBasic)).WithAnsi256(code).applyStyle("x")
+	wantBasic := ansistyles.FgAnsi16(ansistyles.Ansi256ToAnsi16(code)) + "x\u001b[39m"
+	if basic != wantBasic {
+		t.Errorf("WithAnsi256 at LevelBasic = %q, want %q", basic, wantBasic)
+	}
+}
+
+func TestSetLevelReResolvesExistingChain(t *testing.T) {
+	builder := New(ForceLevel(LevelAnsi16m)).WithRGB(10, 20, 30)
+
+	before := builder.applyStyle("x")
+	builder.SetLevel(LevelBasic)
+	after := builder.applyStyle("x")
+
+	if before == after {
+		t.Errorf("applyStyle did not change after SetLevel downgraded the ColorLevel; got %q both times", before)
+	}
+
+	wantAfter := ansistyles.FgAnsi16(ansistyles.RGBToAnsi16(10, 20, 30)) + "x\u001b[39m"
+	if after != wantAfter {
+		t.Errorf("applyStyle after SetLevel(LevelBasic) = %q, want %q", after, wantAfter)
+	}
+}
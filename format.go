@@ -0,0 +1,43 @@
+package gawk
+
+import (
+	"fmt"
+	"io"
+)
+
+// ColorFn is the signature shared by every style accessor (Blue, Red, Bold,
+// and so on), as well as the value returned by Builder.Fn once a style
+// chain such as WithAnsi256(...), WithHex(...) or WithRGB(...) has been
+// resolved against a Builder.  Giving it a name lets callers store a style
+// in a variable, or accept one as a parameter, without having to spell out
+// the underlying function type:
+//
+//	var errorStyle gawk.ColorFn = gawk.WithRed().WithBold().Fn()
+//	fmt.Println(errorStyle("uh oh"))
+type ColorFn func(strs ...string) string
+
+// Fn returns this Builder's style as a ColorFn, decoupling the style from
+// the Builder that produced it so it can be stored in a variable, passed
+// around, or handed to code that only knows about ColorFn.
+func (builder *Builder) Fn() ColorFn {
+	return builder.applyStyle
+}
+
+// Sprintf formats according to a format specifier, exactly like fmt.Sprintf,
+// and then applies the Builder's style to the result.
+func (builder *Builder) Sprintf(format string, a ...interface{}) string {
+	return builder.applyStyle(fmt.Sprintf(format, a...))
+}
+
+// Printf formats according to a format specifier and writes the styled
+// result to builder's default writer (standard output for the root gawk
+// Builder, standard error for gawk.Stderr).
+func (builder *Builder) Printf(format string, a ...interface{}) (int, error) {
+	return fmt.Fprint(builder.defaultWriter(), builder.Sprintf(format, a...))
+}
+
+// Fprintf formats according to a format specifier and writes the styled
+// result to w.
+func (builder *Builder) Fprintf(w io.Writer, format string, a ...interface{}) (int, error) {
+	return fmt.Fprint(w, builder.Sprintf(format, a...))
+}
@@ -0,0 +1,15 @@
+package gawk
+
+import "testing"
+
+func TestBuilderFnReturnsColorFn(t *testing.T) {
+	builder := New(ForceLevel(LevelAnsi16m)).WithRed()
+
+	var fn ColorFn = builder.Fn()
+
+	got := fn("x")
+	want := builder.applyStyle("x")
+	if got != want {
+		t.Errorf("Fn()(\"x\") = %q, want %q", got, want)
+	}
+}
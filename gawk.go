@@ -7,41 +7,42 @@
 //
 // A very simple usage example would be:
 //
-//     fmt.Println(gawk.Blue("This line is blue"))
+//	fmt.Println(gawk.Blue("This line is blue"))
 //
 // Note that this works on all platforms - there's no need to write to a special
 // stream or use a special print function to get color on Windows 10.
 //
 // Some examples:
 //
-//     // Combine styled and normal strings
-//     fmt.Println(gawk.Blue("Hello") + " World" + gawk.Red("!"))
+//	// Combine styled and normal strings
+//	fmt.Println(gawk.Blue("Hello") + " World" + gawk.Red("!"))
 //
-//     // Compose multiple styles using the chainable API
-//     fmt.Println(gawk.WithBlue().WithBgRed().Bold("Hello world!"))
+//	// Compose multiple styles using the chainable API
+//	fmt.Println(gawk.WithBlue().WithBgRed().Bold("Hello world!"))
 //
-//     // Pass in multiple arguments
-//     fmt.Println(gawk.Blue("Hello", "World!", "Foo", "bar", "biz", "baz"))
+//	// Pass in multiple arguments
+//	fmt.Println(gawk.Blue("Hello", "World!", "Foo", "bar", "biz", "baz"))
 //
-//     // Nest styles
-//     fmt.Println(gawk.Green(
-//         "I am a green line " +
-//         gawk.WithBlue().WithUnderline().Bold("with a blue substring") +
-//         " that becomes green again!"
-//     ))
+//	// Nest styles
+//	fmt.Println(gawk.Green(
+//	    "I am a green line " +
+//	    gawk.WithBlue().WithUnderline().Bold("with a blue substring") +
+//	    " that becomes green again!"
+//	))
 //
-//     // Use RGB colors in terminal emulators that support it.
-//     fmt.Println(gawk.WithRGB(123, 45, 67).Underline("Underlined reddish color"))
-//     fmt.Println(gawk.WihHex("#DEADED").Bold("Bold gray!"))
+//	// Use RGB colors in terminal emulators that support it.
+//	fmt.Println(gawk.WithRGB(123, 45, 67).Underline("Underlined reddish color"))
+//	fmt.Println(gawk.WithHex("#DEADED").Bold("Bold gray!"))
 //
-//     // Write to stderr:
-//     os.Stderr.WriteString(gawk.Stderr.Red("Ohs noes!\n"))
+//	// Write to stderr:
+//	os.Stderr.WriteString(gawk.Stderr.Red("Ohs noes!\n"))
 //
 // See the README.md for more details.
-//
 package gawk
 
 import (
+	"io"
+	"os"
 	"strings"
 
 	"github.com/jwalton/go-supportscolor"
@@ -53,10 +54,25 @@ type stylerData struct {
 	openAll  string
 	closeAll string
 	parent   *stylerData
+	// resolve is set instead of open/close/openAll/closeAll for dynamic
+	// styles (WithRGB, WithHex, WithAnsi256) whose actual escape codes
+	// depend on the ColorLevel in effect when the style is applied.
+	resolve func(level ColorLevel) (open string, close string)
+}
+
+// escapesAt returns this frame's open/close escape codes at the given
+// ColorLevel, resolving dynamic styles as needed.
+func (s *stylerData) escapesAt(level ColorLevel) (string, string) {
+	if s.resolve != nil {
+		return s.resolve(level)
+	}
+	return s.open, s.close
 }
 
 type configuration struct {
 	Level ColorLevel
+	// Writer is the destination used by Builder.Print, Println and Printf.
+	Writer io.Writer
 }
 
 // A Builder is used to define and chain together styles.
@@ -64,7 +80,6 @@ type configuration struct {
 // Instances of Builder cannot be constructed directly - you can build a new
 // instance via the New() function, which will give you an instance you can
 // configure without modifying the "default" Builder.
-//
 type Builder struct {
 	bgBlack         *Builder
 	bgBlackBright   *Builder
@@ -126,12 +141,21 @@ func ForceLevel(level ColorLevel) Option {
 	}
 }
 
+// WithWriter is an option that can be passed to `New` to set the io.Writer
+// used by Print, Println and Printf.  Defaults to os.Stdout.
+func WithWriter(w io.Writer) Option {
+	return func(builder *Builder) {
+		builder.config.Writer = w
+	}
+}
+
 // New creates a new instance of Gawk.
 func New(options ...Option) *Builder {
 	builder := &Builder{styler: nil}
 
 	builder.config = &configuration{
-		Level: ColorLevel(supportscolor.Stdout().Level),
+		Level:  ColorLevel(supportscolor.Stdout().Level),
+		Writer: os.Stdout,
 	}
 
 	for index := range options {
@@ -148,6 +172,7 @@ var rootBuilder = New()
 // strings you intend to write the stderr.
 var Stderr = New(
 	ForceLevel(ColorLevel(supportscolor.Stderr().Level)),
+	WithWriter(os.Stderr),
 )
 
 func createBuilder(builder *Builder, open string, close string) *Builder {
@@ -175,6 +200,53 @@ func createBuilder(builder *Builder, open string, close string) *Builder {
 	}
 }
 
+// createDynamicBuilder is like createBuilder, but for styles (WithRGB,
+// WithHex, WithAnsi256) whose escape codes depend on the ColorLevel in
+// effect when the style is applied, rather than being fixed up front.
+func createDynamicBuilder(builder *Builder, resolve func(level ColorLevel) (open string, close string)) *Builder {
+	var parent *stylerData
+	if builder.styler != nil {
+		parent = builder.styler
+	}
+
+	return &Builder{
+		config: builder.config,
+		styler: &stylerData{
+			parent:  parent,
+			resolve: resolve,
+		},
+	}
+}
+
+// stylerEscapes computes the full nested open/close escape sequence for a
+// styler chain at the given ColorLevel.  A chain with no dynamic styles in
+// it reuses its precomputed openAll/closeAll; a chain containing a dynamic
+// style is re-resolved, since the right escape codes depend on level.
+func stylerEscapes(styler *stylerData, level ColorLevel) (string, string) {
+	if !stylerHasDynamic(styler) {
+		return styler.openAll, styler.closeAll
+	}
+	return resolveStylerChain(styler, level)
+}
+
+func stylerHasDynamic(styler *stylerData) bool {
+	for s := styler; s != nil; s = s.parent {
+		if s.resolve != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveStylerChain(styler *stylerData, level ColorLevel) (string, string) {
+	if styler == nil {
+		return "", ""
+	}
+	parentOpen, parentClose := resolveStylerChain(styler.parent, level)
+	open, close := styler.escapesAt(level)
+	return parentOpen + open, close + parentClose
+}
+
 func (builder *Builder) applyStyle(strs ...string) string {
 	if len(strs) == 0 {
 		return ""
@@ -191,15 +263,21 @@ func (builder *Builder) applyStyle(strs ...string) string {
 		return str
 	}
 
-	openAll := styler.openAll
-	closeAll := styler.closeAll
+	level := LevelAnsi16m
+	if builder.config != nil {
+		level = builder.config.Level
+	}
+
+	openAll, closeAll := stylerEscapes(styler, level)
 
 	if strings.Contains(str, "\u001B") {
-		for styler != nil {
+		for s := styler; s != nil; s = s.parent {
+			open, close := s.escapesAt(level)
+
 			// Replace any instances already present with a re-opening code
 			// otherwise only the part of the string until said closing code
 			// will be colored, and the rest will simply be 'plain'.
-			if styler.close == "\u001b[22m" {
+			if close == "\u001b[22m" {
 				// This is kind of a weird corner case - both "bold" and "dim"
 				// close with "22", but these are actually not mutually exclusive
 				// styles - you can have something both bold and dim at the same
@@ -213,12 +291,10 @@ func (builder *Builder) applyStyle(strs ...string) string {
 				// case this is pointless (as a string can't be both red and
 				// blue at the same time, for example), so we treat this as a
 				// special case.
-				str = strings.ReplaceAll(str, styler.close, styler.close+styler.open)
+				str = strings.ReplaceAll(str, close, close+open)
 			} else {
-				str = strings.ReplaceAll(str, styler.close, styler.open)
+				str = strings.ReplaceAll(str, close, open)
 			}
-
-			styler = styler.parent
 		}
 	}
 
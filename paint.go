@@ -0,0 +1,261 @@
+package gawk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jwalton/gawk/pkg/ansistyles"
+)
+
+// ParseError describes a problem found while parsing a Paint template.  Offset
+// is the byte offset into the template where the problem was detected, which
+// can be used to point a user at the exact spot a tag went wrong.
+type ParseError struct {
+	Message string
+	Offset  int
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("gawk: %s (at offset %d)", e.Message, e.Offset)
+}
+
+// styleResolvers maps a style name, as it appears inside a `{name ...}` tag,
+// to the chain method used to obtain the corresponding sub-Builder.
+var styleResolvers = map[string]func(*Builder) *Builder{
+	"bgBlack":         (*Builder).WithBgBlack,
+	"bgBlackBright":   (*Builder).WithBgBlackBright,
+	"bgBlue":          (*Builder).WithBgBlue,
+	"bgBlueBright":    (*Builder).WithBgBlueBright,
+	"bgCyan":          (*Builder).WithBgCyan,
+	"bgCyanBright":    (*Builder).WithBgCyanBright,
+	"bgGray":          (*Builder).WithBgGray,
+	"bgGreen":         (*Builder).WithBgGreen,
+	"bgGreenBright":   (*Builder).WithBgGreenBright,
+	"bgGrey":          (*Builder).WithBgGrey,
+	"bgMagenta":       (*Builder).WithBgMagenta,
+	"bgMagentaBright": (*Builder).WithBgMagentaBright,
+	"bgRed":           (*Builder).WithBgRed,
+	"bgRedBright":     (*Builder).WithBgRedBright,
+	"bgWhite":         (*Builder).WithBgWhite,
+	"bgWhiteBright":   (*Builder).WithBgWhiteBright,
+	"bgYellow":        (*Builder).WithBgYellow,
+	"bgYellowBright":  (*Builder).WithBgYellowBright,
+	"black":           (*Builder).WithBlack,
+	"blackBright":     (*Builder).WithBlackBright,
+	"blue":            (*Builder).WithBlue,
+	"blueBright":      (*Builder).WithBlueBright,
+	"cyan":            (*Builder).WithCyan,
+	"cyanBright":      (*Builder).WithCyanBright,
+	"gray":            (*Builder).WithGray,
+	"green":           (*Builder).WithGreen,
+	"greenBright":     (*Builder).WithGreenBright,
+	"grey":            (*Builder).WithGrey,
+	"magenta":         (*Builder).WithMagenta,
+	"magentaBright":   (*Builder).WithMagentaBright,
+	"red":             (*Builder).WithRed,
+	"redBright":       (*Builder).WithRedBright,
+	"white":           (*Builder).WithWhite,
+	"whiteBright":     (*Builder).WithWhiteBright,
+	"yellow":          (*Builder).WithYellow,
+	"yellowBright":    (*Builder).WithYellowBright,
+	"bold":            (*Builder).WithBold,
+	"dim":             (*Builder).WithDim,
+	"hidden":          (*Builder).WithHidden,
+	"inverse":         (*Builder).WithInverse,
+	"italic":          (*Builder).WithItalic,
+	"overline":        (*Builder).WithOverline,
+	"strikethrough":   (*Builder).WithStrikethrough,
+	"underline":       (*Builder).WithUnderline,
+	"reset":           (*Builder).WithReset,
+}
+
+// Paint parses a small markup language and returns the styled result, or an
+// error if the template is malformed.
+//
+// A style (or a dot-separated chain of styles) is applied to everything up
+// to the matching closing brace:
+//
+//	gawk.Paint("{red.bold Hello} {blue World}")
+//
+// Tags may be nested, in which case the inner style is layered on top of the
+// outer one exactly as it would be if the builders had been chained by hand.
+// Colors that take a parameter can be specified dynamically using `#RRGGBB`
+// for a hex color, `rgb(r,g,b)` for a truecolor value, or `ansi(n)` for an
+// ANSI 256 color, e.g. `{#DEADED Bold gray}` or `{rgb(123,45,67) hi}`. A
+// literal brace is produced by escaping it: `\{` or `\}`.
+func Paint(template string) (string, error) {
+	return rootBuilder.Paint(template)
+}
+
+// Paint is like the package-level Paint, but resolves styles - and therefore
+// the eventual ColorLevel - relative to this Builder.
+func (builder *Builder) Paint(template string) (string, error) {
+	p := &paintParser{src: template, builder: builder}
+
+	result, err := p.parseSegment(false)
+	if err != nil {
+		return "", err
+	}
+	if p.pos < len(p.src) {
+		return "", &ParseError{Message: "unmatched '}'", Offset: p.pos}
+	}
+
+	return result, nil
+}
+
+type paintParser struct {
+	src     string
+	pos     int
+	builder *Builder
+}
+
+// parseSegment consumes text (and any tags within it) until it hits the end
+// of the string or, if inTag is true, an unescaped closing brace.
+func (p *paintParser) parseSegment(inTag bool) (string, error) {
+	var out strings.Builder
+
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+
+		switch {
+		case c == '\\' && p.pos+1 < len(p.src) && isEscapable(p.src[p.pos+1]):
+			out.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+		case c == '{':
+			styled, err := p.parseTag()
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(styled)
+		case c == '}':
+			if inTag {
+				return out.String(), nil
+			}
+			return "", &ParseError{Message: "unmatched '}'", Offset: p.pos}
+		default:
+			out.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	if inTag {
+		return "", &ParseError{Message: "unterminated tag, expected '}'", Offset: p.pos}
+	}
+
+	return out.String(), nil
+}
+
+func isEscapable(c byte) bool {
+	return c == '{' || c == '}' || c == '\\'
+}
+
+// parseTag consumes a `{chain content}` tag starting at the current '{', and
+// returns the styled content.
+func (p *paintParser) parseTag() (string, error) {
+	start := p.pos
+	p.pos++ // consume '{'
+
+	nameStart := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] != ' ' && p.src[p.pos] != '}' {
+		p.pos++
+	}
+	if p.pos == nameStart {
+		return "", &ParseError{Message: "expected a style name after '{'", Offset: start}
+	}
+	chain := p.src[nameStart:p.pos]
+
+	styled, err := resolveStyleChain(p.builder, chain)
+	if err != nil {
+		return "", &ParseError{Message: err.Error(), Offset: nameStart}
+	}
+
+	if p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+
+	inner := &paintParser{src: p.src, pos: p.pos, builder: styled}
+	content, err := inner.parseSegment(true)
+	if err != nil {
+		return "", err
+	}
+	p.pos = inner.pos
+
+	if p.pos >= len(p.src) || p.src[p.pos] != '}' {
+		return "", &ParseError{Message: "unterminated tag, expected '}'", Offset: start}
+	}
+	p.pos++ // consume '}'
+
+	return styled.applyStyle(content), nil
+}
+
+// resolveStyleChain resolves a dot-separated chain of style names (e.g.
+// "red.bold") into a Builder with all of those styles applied in order.
+func resolveStyleChain(builder *Builder, chain string) (*Builder, error) {
+	for _, name := range strings.Split(chain, ".") {
+		next, err := resolveStyleName(builder, name)
+		if err != nil {
+			return nil, err
+		}
+		builder = next
+	}
+	return builder, nil
+}
+
+func resolveStyleName(builder *Builder, name string) (*Builder, error) {
+	if resolver, ok := styleResolvers[name]; ok {
+		return resolver(builder), nil
+	}
+
+	if strings.HasPrefix(name, "#") {
+		if _, _, _, err := ansistyles.HexToRGB(name); err != nil {
+			return nil, fmt.Errorf("invalid hex style %q", name)
+		}
+		return builder.WithHex(name), nil
+	}
+
+	if args, ok := tagArgs(name, "rgb"); ok {
+		parts := strings.Split(args, ",")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid rgb() style %q", name)
+		}
+		r, errR := strconv.Atoi(strings.TrimSpace(parts[0]))
+		g, errG := strconv.Atoi(strings.TrimSpace(parts[1]))
+		b, errB := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if errR != nil || errG != nil || errB != nil {
+			return nil, fmt.Errorf("invalid rgb() style %q", name)
+		}
+		if !isByteValue(r) || !isByteValue(g) || !isByteValue(b) {
+			return nil, fmt.Errorf("rgb() component out of range 0-255 in %q", name)
+		}
+		return builder.WithRGB(r, g, b), nil
+	}
+
+	if args, ok := tagArgs(name, "ansi"); ok {
+		code, err := strconv.Atoi(strings.TrimSpace(args))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ansi() style %q", name)
+		}
+		if !isByteValue(code) {
+			return nil, fmt.Errorf("ansi() code out of range 0-255 in %q", name)
+		}
+		return builder.WithAnsi256(code), nil
+	}
+
+	return nil, fmt.Errorf("unknown style %q", name)
+}
+
+// isByteValue reports whether n is in the 0-255 range a color component or
+// an xterm-256 code must fall into.
+func isByteValue(n int) bool {
+	return n >= 0 && n <= 255
+}
+
+// tagArgs checks if name has the form "fn(args)", and if so returns args.
+func tagArgs(name string, fn string) (string, bool) {
+	prefix := fn + "("
+	if strings.HasPrefix(name, prefix) && strings.HasSuffix(name, ")") {
+		return name[len(prefix) : len(name)-1], true
+	}
+	return "", false
+}
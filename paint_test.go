@@ -0,0 +1,127 @@
+package gawk
+
+import (
+	"strings"
+	"testing"
+)
+
+func newTestBuilder() *Builder {
+	return New(ForceLevel(LevelAnsi16m))
+}
+
+func TestPaintSimpleTag(t *testing.T) {
+	builder := newTestBuilder()
+
+	got, err := builder.Paint("{red Hello}")
+	if err != nil {
+		t.Fatalf("Paint returned unexpected error: %v", err)
+	}
+
+	want := builder.WithRed().applyStyle("Hello")
+	if got != want {
+		t.Errorf("Paint(%q) = %q, want %q", "{red Hello}", got, want)
+	}
+}
+
+func TestPaintChainedAndNestedTags(t *testing.T) {
+	builder := newTestBuilder()
+
+	got, err := builder.Paint("{red.bold Hello} {blue World}")
+	if err != nil {
+		t.Fatalf("Paint returned unexpected error: %v", err)
+	}
+
+	want := builder.WithRed().WithBold().applyStyle("Hello") + " " + builder.WithBlue().applyStyle("World")
+	if got != want {
+		t.Errorf("Paint chained tags = %q, want %q", got, want)
+	}
+
+	got, err = builder.Paint("{green outer {blue inner} outer again}")
+	if err != nil {
+		t.Fatalf("Paint returned unexpected error: %v", err)
+	}
+
+	inner := builder.WithGreen().WithBlue().applyStyle("inner")
+	want = builder.WithGreen().applyStyle("outer " + inner + " outer again")
+	if got != want {
+		t.Errorf("Paint nested tags = %q, want %q", got, want)
+	}
+}
+
+func TestPaintEscapedBraces(t *testing.T) {
+	got, err := Paint(`\{not a tag\}`)
+	if err != nil {
+		t.Fatalf("Paint returned unexpected error: %v", err)
+	}
+	if got != "{not a tag}" {
+		t.Errorf("Paint escaped braces = %q, want %q", got, "{not a tag}")
+	}
+}
+
+func TestPaintDynamicStyles(t *testing.T) {
+	builder := newTestBuilder()
+
+	cases := map[string]*Builder{
+		"{#336699 hex}":       builder.WithHex("#336699"),
+		"{rgb(10,20,30) rgb}": builder.WithRGB(10, 20, 30),
+		"{ansi(200) ansi}":    builder.WithAnsi256(200),
+	}
+
+	for tag, expectedBuilder := range cases {
+		content := tag[strings.Index(tag, " ")+1 : len(tag)-1]
+		got, err := builder.Paint(tag)
+		if err != nil {
+			t.Fatalf("Paint(%q) returned unexpected error: %v", tag, err)
+		}
+		want := expectedBuilder.applyStyle(content)
+		if got != want {
+			t.Errorf("Paint(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestPaintErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+	}{
+		{"unmatched closing brace", "hello}"},
+		{"unterminated tag", "{red hello"},
+		{"empty tag name", "{ hello}"},
+		{"unknown style", "{notacolor hello}"},
+		{"malformed hex", "{#zzzzzz oops}"},
+		{"malformed rgb", "{rgb(1,2) oops}"},
+		{"malformed ansi", "{ansi(abc) oops}"},
+		{"rgb component too high", "{rgb(999,-5,300) oops}"},
+		{"rgb component negative", "{rgb(0,0,-1) oops}"},
+		{"ansi code too high", "{ansi(256) oops}"},
+		{"ansi code negative", "{ansi(-5) oops}"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Paint(c.template)
+			if err == nil {
+				t.Fatalf("Paint(%q) = nil error, want a ParseError", c.template)
+			}
+			if _, ok := err.(*ParseError); !ok {
+				t.Fatalf("Paint(%q) error = %T, want *ParseError", c.template, err)
+			}
+		})
+	}
+}
+
+func TestPaintMalformedHexDoesNotSilentlyNoOp(t *testing.T) {
+	_, err := Paint("{#zzzzzz oops}")
+	if err == nil {
+		t.Fatal("Paint with malformed hex color silently succeeded, want a ParseError")
+	}
+
+	parseErr, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("error = %T, want *ParseError", err)
+	}
+	if !strings.Contains(parseErr.Message, "hex") {
+		t.Errorf("ParseError.Message = %q, want it to mention the invalid hex value", parseErr.Message)
+	}
+}
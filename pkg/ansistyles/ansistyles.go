@@ -0,0 +1,158 @@
+// Package ansistyles provides low-level helpers for building ANSI escape
+// codes, including the nearest-color conversions gawk uses to downgrade
+// truecolor and xterm-256 styles for terminals that only support a smaller
+// palette.
+package ansistyles
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ansi16Palette holds the RGB value of each of the 16 basic ANSI colors, in
+// code order (0-7 normal, 8-15 bright).
+var ansi16Palette = [16][3]int{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+	{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// ansi256Palette holds the RGB value of each of the 256 xterm colors, used
+// to find the nearest match when downgrading from truecolor.
+var ansi256Palette = buildAnsi256Palette()
+
+func buildAnsi256Palette() [256][3]int {
+	var palette [256][3]int
+
+	copy(palette[:16], ansi16Palette[:])
+
+	// The 6x6x6 color cube, codes 16-231.
+	steps := [6]int{0, 95, 135, 175, 215, 255}
+	i := 16
+	for r := 0; r < 6; r++ {
+		for g := 0; g < 6; g++ {
+			for b := 0; b < 6; b++ {
+				palette[i] = [3]int{steps[r], steps[g], steps[b]}
+				i++
+			}
+		}
+	}
+
+	// The grayscale ramp, codes 232-255.
+	for shade := 0; shade < 24; shade++ {
+		level := 8 + shade*10
+		palette[232+shade] = [3]int{level, level, level}
+	}
+
+	return palette
+}
+
+// HexToRGB parses a "#RRGGBB" or "#RGB" string into its red, green and blue
+// components.
+func HexToRGB(hex string) (r int, g int, b int, err error) {
+	hex = strings.TrimPrefix(hex, "#")
+
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, 0, 0, fmt.Errorf("ansistyles: invalid hex color %q", hex)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("ansistyles: invalid hex color %q", hex)
+	}
+
+	return int(value>>16) & 0xFF, int(value>>8) & 0xFF, int(value) & 0xFF, nil
+}
+
+// RGBToAnsi256 returns the code (0-255) of the xterm-256 color nearest to
+// the given RGB value.
+func RGBToAnsi256(r int, g int, b int) int {
+	return nearestColor(ansi256Palette[:], r, g, b)
+}
+
+// RGBToAnsi16 returns the code (0-15) of the basic ANSI color nearest to the
+// given RGB value.
+func RGBToAnsi16(r int, g int, b int) int {
+	return nearestColor(ansi16Palette[:], r, g, b)
+}
+
+// Ansi256ToAnsi16 downgrades an xterm-256 color code to the nearest basic
+// ANSI color.
+func Ansi256ToAnsi16(code int) int {
+	if code < 0 || code > 255 {
+		return 0
+	}
+	c := ansi256Palette[code]
+	return RGBToAnsi16(c[0], c[1], c[2])
+}
+
+// nearestColor returns the index into palette of the color with the
+// smallest perceptual distance to (r, g, b).
+func nearestColor(palette [][3]int, r int, g int, b int) int {
+	best := 0
+	bestDistance := -1
+
+	for i, c := range palette {
+		distance := colorDistance(c[0], c[1], c[2], r, g, b)
+		if bestDistance == -1 || distance < bestDistance {
+			bestDistance = distance
+			best = i
+		}
+	}
+
+	return best
+}
+
+// colorDistance approximates the perceptual distance between two colors
+// using the "redmean" weighting, a low-cost stand-in for full CIE76/CIE94
+// delta-E that accounts for the eye's uneven sensitivity across channels.
+func colorDistance(r1 int, g1 int, b1 int, r2 int, g2 int, b2 int) int {
+	rMean := (r1 + r2) / 2
+	dr := r1 - r2
+	dg := g1 - g2
+	db := b1 - b2
+	return ((512+rMean)*dr*dr)>>8 + 4*dg*dg + ((767-rMean)*db*db)>>8
+}
+
+// FgTrueColor returns the foreground escape code for a 24-bit RGB color.
+func FgTrueColor(r int, g int, b int) string {
+	return fmt.Sprintf("\u001b[38;2;%d;%d;%dm", r, g, b)
+}
+
+// BgTrueColor returns the background escape code for a 24-bit RGB color.
+func BgTrueColor(r int, g int, b int) string {
+	return fmt.Sprintf("\u001b[48;2;%d;%d;%dm", r, g, b)
+}
+
+// FgAnsi256 returns the foreground escape code for an xterm-256 color.
+func FgAnsi256(code int) string {
+	return fmt.Sprintf("\u001b[38;5;%dm", code)
+}
+
+// BgAnsi256 returns the background escape code for an xterm-256 color.
+func BgAnsi256(code int) string {
+	return fmt.Sprintf("\u001b[48;5;%dm", code)
+}
+
+// FgAnsi16 returns the foreground escape code for a basic ANSI color
+// (0-15).
+func FgAnsi16(code int) string {
+	if code < 8 {
+		return fmt.Sprintf("\u001b[%dm", 30+code)
+	}
+	return fmt.Sprintf("\u001b[%dm", 82+code)
+}
+
+// BgAnsi16 returns the background escape code for a basic ANSI color
+// (0-15).
+func BgAnsi16(code int) string {
+	if code < 8 {
+		return fmt.Sprintf("\u001b[%dm", 40+code)
+	}
+	return fmt.Sprintf("\u001b[%dm", 92+code)
+}
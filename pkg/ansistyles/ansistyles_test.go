@@ -0,0 +1,147 @@
+package ansistyles
+
+import "testing"
+
+func TestHexToRGB(t *testing.T) {
+	cases := []struct {
+		hex     string
+		r, g, b int
+	}{
+		{"#fff", 255, 255, 255},
+		{"#000000", 0, 0, 0},
+		{"#336699", 51, 102, 153},
+		{"336699", 51, 102, 153},
+	}
+
+	for _, c := range cases {
+		r, g, b, err := HexToRGB(c.hex)
+		if err != nil {
+			t.Errorf("HexToRGB(%q) returned unexpected error: %v", c.hex, err)
+			continue
+		}
+		if r != c.r || g != c.g || b != c.b {
+			t.Errorf("HexToRGB(%q) = (%d, %d, %d), want (%d, %d, %d)", c.hex, r, g, b, c.r, c.g, c.b)
+		}
+	}
+}
+
+func TestHexToRGBInvalid(t *testing.T) {
+	cases := []string{"#zzzzzz", "#12345", "", "#1234567"}
+
+	for _, hex := range cases {
+		if _, _, _, err := HexToRGB(hex); err == nil {
+			t.Errorf("HexToRGB(%q) = nil error, want an error", hex)
+		}
+	}
+}
+
+func TestColorDistance(t *testing.T) {
+	cases := []struct {
+		c1, c2 [3]int
+		want   int
+	}{
+		{[3]int{0, 0, 0}, [3]int{0, 0, 0}, 0},
+		{[3]int{10, 20, 30}, [3]int{10, 20, 30}, 0},
+		{[3]int{0, 0, 0}, [3]int{255, 0, 0}, 162308},
+	}
+
+	for _, c := range cases {
+		got := colorDistance(c.c1[0], c.c1[1], c.c1[2], c.c2[0], c.c2[1], c.c2[2])
+		if got != c.want {
+			t.Errorf("colorDistance(%v, %v) = %d, want %d", c.c1, c.c2, got, c.want)
+		}
+	}
+}
+
+func TestRGBToAnsi256(t *testing.T) {
+	cases := []struct {
+		r, g, b int
+		want    int
+	}{
+		{255, 0, 0, 9},     // exact match against basic-16 bright red
+		{0, 0, 0, 0},       // exact match against basic-16 black
+		{95, 135, 175, 67}, // exact match against a 6x6x6 cube step
+		{1, 2, 3, 0},       // nearest neighbor is basic-16 black
+		{200, 200, 200, 251},
+	}
+
+	for _, c := range cases {
+		got := RGBToAnsi256(c.r, c.g, c.b)
+		if got != c.want {
+			t.Errorf("RGBToAnsi256(%d, %d, %d) = %d, want %d", c.r, c.g, c.b, got, c.want)
+		}
+	}
+}
+
+func TestRGBToAnsi16(t *testing.T) {
+	cases := []struct {
+		r, g, b int
+		want    int
+	}{
+		{255, 0, 0, 9},
+		{0, 0, 0, 0},
+		{200, 200, 200, 7},
+	}
+
+	for _, c := range cases {
+		got := RGBToAnsi16(c.r, c.g, c.b)
+		if got != c.want {
+			t.Errorf("RGBToAnsi16(%d, %d, %d) = %d, want %d", c.r, c.g, c.b, got, c.want)
+		}
+	}
+}
+
+func TestAnsi256ToAnsi16(t *testing.T) {
+	cases := []struct {
+		code int
+		want int
+	}{
+		{196, 9},  // cube red -> basic-16 bright red
+		{9, 9},    // basic-16 entries map to themselves
+		{231, 15}, // cube white -> basic-16 white
+		{232, 0},  // darkest greyscale step -> basic-16 black
+	}
+
+	for _, c := range cases {
+		got := Ansi256ToAnsi16(c.code)
+		if got != c.want {
+			t.Errorf("Ansi256ToAnsi16(%d) = %d, want %d", c.code, got, c.want)
+		}
+	}
+}
+
+func TestAnsi256ToAnsi16OutOfRange(t *testing.T) {
+	if got := Ansi256ToAnsi16(-1); got != 0 {
+		t.Errorf("Ansi256ToAnsi16(-1) = %d, want 0", got)
+	}
+	if got := Ansi256ToAnsi16(256); got != 0 {
+		t.Errorf("Ansi256ToAnsi16(256) = %d, want 0", got)
+	}
+}
+
+func TestFgBgEscapeCodes(t *testing.T) {
+	if got, want := FgTrueColor(1, 2, 3), "\u001b[38;2;1;2;3m"; got != want {
+		t.Errorf("FgTrueColor(1, 2, 3) = %q, want %q", got, want)
+	}
+	if got, want := BgTrueColor(1, 2, 3), "\u001b[48;2;1;2;3m"; got != want {
+		t.Errorf("BgTrueColor(1, 2, 3) = %q, want %q", got, want)
+	}
+	if got, want := FgAnsi256(200), "\u001b[38;5;200m"; got != want {
+		t.Errorf("FgAnsi256(200) = %q, want %q", got, want)
+	}
+	if got, want := BgAnsi256(200), "\u001b[48;5;200m"; got != want {
+		t.Errorf("BgAnsi256(200) = %q, want %q", got, want)
+	}
+	if got, want := FgAnsi16(0), "\u001b[30m"; got != want {
+		t.Errorf("FgAnsi16(0) = %q, want %q", got, want)
+	}
+	if got, want := FgAnsi16(9), "\u001b[91m"; got != want {
+		t.Errorf("FgAnsi16(9) = %q, want %q", got, want)
+	}
+	if got, want := BgAnsi16(0), "\u001b[40m"; got != want {
+		t.Errorf("BgAnsi16(0) = %q, want %q", got, want)
+	}
+	if got, want := BgAnsi16(9), "\u001b[101m"; got != want {
+		t.Errorf("BgAnsi16(9) = %q, want %q", got, want)
+	}
+}
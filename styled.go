@@ -0,0 +1,87 @@
+package gawk
+
+import "strings"
+
+// StyledText pairs raw text with a Builder's style, without baking that
+// style into ANSI escape codes until it is actually rendered.  This makes
+// it a good return type for library code that wants to hand back colored
+// output but let the eventual caller's terminal decide how much of that
+// color it can show - unlike a plain string returned by e.g. Builder.Red,
+// a StyledText can still be rendered at a different ColorLevel later on.
+type StyledText struct {
+	segments []styledSegment
+}
+
+// styledSegment is a single run of text paired with the Builder that should
+// style it.  A nil builder means the text is unstyled.
+type styledSegment struct {
+	text    string
+	builder *Builder
+}
+
+// Styled captures the Builder's current style and the given strings as a
+// StyledText, to be rendered later via String() or RenderAt().
+func (builder *Builder) Styled(strs ...string) StyledText {
+	return StyledText{segments: []styledSegment{{text: strings.Join(strs, " "), builder: builder}}}
+}
+
+// String renders the StyledText using each segment's own Builder's
+// currently configured ColorLevel.
+func (st StyledText) String() string {
+	var combined strings.Builder
+	for _, segment := range st.segments {
+		if segment.builder == nil {
+			combined.WriteString(segment.text)
+			continue
+		}
+		combined.WriteString(segment.builder.applyStyle(segment.text))
+	}
+	return combined.String()
+}
+
+// RenderAt renders the StyledText as though every segment's Builder had
+// been configured for the given ColorLevel, without otherwise disturbing
+// the Builders it was created from.
+func (st StyledText) RenderAt(level ColorLevel) string {
+	var combined strings.Builder
+	for _, segment := range st.segments {
+		combined.WriteString(segment.renderAt(level))
+	}
+	return combined.String()
+}
+
+// renderAt renders the segment as though its Builder had been configured
+// for the given ColorLevel.
+func (segment styledSegment) renderAt(level ColorLevel) string {
+	if segment.builder == nil {
+		return segment.text
+	}
+
+	leveled := *segment.builder
+	config := *segment.builder.config
+	config.Level = level
+	leveled.config = &config
+
+	return leveled.applyStyle(segment.text)
+}
+
+// Plain strips all styling and returns the raw text.
+func (st StyledText) Plain() string {
+	var combined strings.Builder
+	for _, segment := range st.segments {
+		combined.WriteString(segment.text)
+	}
+	return combined.String()
+}
+
+// Concat joins this StyledText with others, preserving each segment's own
+// Builder so the result can still be rendered at a different ColorLevel via
+// String() or RenderAt().
+func (st StyledText) Concat(others ...StyledText) StyledText {
+	combined := make([]styledSegment, 0, len(st.segments))
+	combined = append(combined, st.segments...)
+	for _, other := range others {
+		combined = append(combined, other.segments...)
+	}
+	return StyledText{segments: combined}
+}
@@ -0,0 +1,67 @@
+package gawk
+
+import "testing"
+
+func TestStyledTextString(t *testing.T) {
+	builder := New(ForceLevel(LevelAnsi16m)).WithRed()
+
+	st := builder.Styled("hello")
+	got := st.String()
+	want := builder.applyStyle("hello")
+	if got != want {
+		t.Errorf("Styled(\"hello\").String() = %q, want %q", got, want)
+	}
+}
+
+func TestStyledTextRenderAt(t *testing.T) {
+	builder := New(ForceLevel(LevelAnsi16m)).WithRGB(10, 20, 30)
+	st := builder.Styled("hello")
+
+	basic := st.RenderAt(LevelBasic)
+	ansi256 := st.RenderAt(LevelAnsi256)
+	truecolor := st.RenderAt(LevelAnsi16m)
+
+	if basic == ansi256 || ansi256 == truecolor || basic == truecolor {
+		t.Errorf("RenderAt produced the same output at different levels: basic=%q ansi256=%q truecolor=%q", basic, ansi256, truecolor)
+	}
+
+	// RenderAt must not disturb the Builder it was created from.
+	if got, want := st.String(), builder.applyStyle("hello"); got != want {
+		t.Errorf("String() after RenderAt = %q, want %q (Builder should be unaffected)", got, want)
+	}
+}
+
+func TestStyledTextPlain(t *testing.T) {
+	builder := New(ForceLevel(LevelAnsi16m)).WithBlue()
+	st := builder.Styled("hello", "world")
+
+	if got, want := st.Plain(), "hello world"; got != want {
+		t.Errorf("Plain() = %q, want %q", got, want)
+	}
+}
+
+func TestStyledTextConcatThenRenderAt(t *testing.T) {
+	red := New(ForceLevel(LevelAnsi16m)).WithRed()
+	blue := New(ForceLevel(LevelAnsi16m)).WithRGB(10, 20, 30)
+
+	combined := red.Styled("red").Concat(blue.Styled("blue"))
+
+	// The regression this guards against: Concat used to bake its result
+	// down to a plain string at the current level, so a later RenderAt at
+	// a different level was silently a no-op. blue's RGB segment is the
+	// one whose escapes actually change between levels.
+	basic := combined.RenderAt(LevelBasic)
+	truecolor := combined.RenderAt(LevelAnsi16m)
+	if basic == truecolor {
+		t.Errorf("Concat result rendered the same at LevelBasic and LevelAnsi16m: %q", basic)
+	}
+
+	want := red.applyStyle("red") + blue.applyStyle("blue")
+	if got := combined.String(); got != want {
+		t.Errorf("Concat(...).String() = %q, want %q", got, want)
+	}
+
+	if got, want := combined.Plain(), "redblue"; got != want {
+		t.Errorf("Concat(...).Plain() = %q, want %q", got, want)
+	}
+}
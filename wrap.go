@@ -0,0 +1,49 @@
+package gawk
+
+import "fmt"
+
+// Wrap drops a string styled with "other" into the middle of a string
+// styled with "builder", without losing "builder"'s styling afterwards.
+// This is useful for building up a colored string piece-by-piece (for
+// example with a strings.Builder or an io.Writer) when the pieces need
+// different styles but the surrounding style should stay active across the
+// boundary:
+//
+//	outer := gawk.WithGreen()
+//	inner := gawk.WithBlue().WithUnderline()
+//	fmt.Println(outer.Wrap(inner, "with a blue substring"))
+//
+// Unlike nesting via applyStyle, which requires the inner string to be fully
+// formed before the outer style is applied to it, Wrap only needs the
+// styling information for the two Builders involved, so the inner string
+// can be written separately and the outer style reopened immediately after.
+func (builder *Builder) Wrap(other *Builder, s string) string {
+	if builder.config != nil && builder.config.Level <= LevelNone {
+		return s
+	}
+
+	level := LevelAnsi16m
+	if builder.config != nil {
+		level = builder.config.Level
+	}
+
+	builderOpenAll, builderCloseAll := stylerChainEscapes(builder, level)
+	otherOpenAll, otherCloseAll := stylerChainEscapes(other, level)
+
+	return builderCloseAll + otherOpenAll + s + otherCloseAll + builderOpenAll
+}
+
+// Wrapf is like Wrap, but formats s with fmt.Sprintf before wrapping it.
+func (builder *Builder) Wrapf(other *Builder, format string, args ...interface{}) string {
+	return builder.Wrap(other, fmt.Sprintf(format, args...))
+}
+
+// stylerChainEscapes returns the full chain of opening/closing escape codes
+// for builder at the given ColorLevel, or "", "" if builder has no style
+// applied.
+func stylerChainEscapes(builder *Builder, level ColorLevel) (string, string) {
+	if builder == nil || builder.styler == nil {
+		return "", ""
+	}
+	return stylerEscapes(builder.styler, level)
+}
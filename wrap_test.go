@@ -0,0 +1,73 @@
+package gawk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapPreservesOuterStyleAcrossMultiStyleChain(t *testing.T) {
+	outer := New(ForceLevel(LevelAnsi16m)).WithGreen()
+	inner := New(ForceLevel(LevelAnsi16m)).WithBlue().WithUnderline()
+
+	got := outer.Wrap(inner, "x")
+
+	wantOpenAll, wantCloseAll := stylerEscapes(inner.styler, LevelAnsi16m)
+	outerOpenAll, outerCloseAll := stylerEscapes(outer.styler, LevelAnsi16m)
+	want := outerCloseAll + wantOpenAll + "x" + wantCloseAll + outerOpenAll
+
+	if got != want {
+		t.Errorf("Wrap() = %q, want %q", got, want)
+	}
+
+	// The regression this guards against: the blue color code from the
+	// inner chain must actually be emitted, not just underline's.
+	if !strings.Contains(got, wantOpenAll) {
+		t.Errorf("Wrap() = %q, missing inner chain's full open sequence %q", got, wantOpenAll)
+	}
+}
+
+func TestWrapRespectsLevelNone(t *testing.T) {
+	outer := New(ForceLevel(LevelNone)).WithGreen()
+	inner := New(ForceLevel(LevelNone)).WithBlue()
+
+	got := outer.Wrap(inner, "plain")
+	if got != "plain" {
+		t.Errorf("Wrap() at LevelNone = %q, want %q", got, "plain")
+	}
+}
+
+func TestWrapWithDynamicStyleInChain(t *testing.T) {
+	outer := New(ForceLevel(LevelAnsi256)).WithGreen()
+	inner := New(ForceLevel(LevelAnsi256)).WithRGB(10, 20, 30).WithBold()
+
+	got := outer.Wrap(inner, "x")
+
+	innerOpenAll, innerCloseAll := stylerEscapes(inner.styler, LevelAnsi256)
+	outerOpenAll, outerCloseAll := stylerEscapes(outer.styler, LevelAnsi256)
+	want := outerCloseAll + innerOpenAll + "x" + innerCloseAll + outerOpenAll
+
+	if got != want {
+		t.Errorf("Wrap() with dynamic style in chain = %q, want %q", got, want)
+	}
+}
+
+func TestWrapNestedDimInsideBoldClosingCode(t *testing.T) {
+	// "bold" and "dim" both close with "\u001b[22m"; Wrap must still emit
+	// both halves of the chain rather than losing one to the shared close
+	// code, the tricky case called out for applyStyle's own nesting.
+	outer := New(ForceLevel(LevelAnsi16m)).WithBold()
+	inner := outer.WithDim()
+
+	got := outer.Wrap(inner, "x")
+
+	innerOpenAll, innerCloseAll := stylerEscapes(inner.styler, LevelAnsi16m)
+	outerOpenAll, outerCloseAll := stylerEscapes(outer.styler, LevelAnsi16m)
+	want := outerCloseAll + innerOpenAll + "x" + innerCloseAll + outerOpenAll
+
+	if got != want {
+		t.Errorf("Wrap() with nested dim-in-bold = %q, want %q", got, want)
+	}
+	if !strings.Contains(got, "\u001b[22m") {
+		t.Errorf("Wrap() = %q, want it to contain the shared bold/dim close code", got)
+	}
+}
@@ -0,0 +1,57 @@
+package gawk
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// colorWriter applies a Builder's style to everything written through it,
+// the same way applyStyle does for a plain string.
+type colorWriter struct {
+	w       io.Writer
+	builder *Builder
+}
+
+// NewColorWriter returns an io.Writer that styles everything written to it
+// with builder before passing it on to w.
+func NewColorWriter(w io.Writer, builder *Builder) io.Writer {
+	return &colorWriter{w: w, builder: builder}
+}
+
+func (cw *colorWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(cw.w, cw.builder.applyStyle(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Writer returns an io.Writer that applies builder's style to everything
+// written to it before passing the result on to w.  This gives libraries
+// that write to an io.Writer - log.Logger, tabwriter, a text/template
+// executor - a natural way to produce styled output, without every call
+// site having to be rewritten to build and wrap strings by hand.
+func (builder *Builder) Writer(w io.Writer) io.Writer {
+	return NewColorWriter(w, builder)
+}
+
+// defaultWriter returns the writer Print, Println and Printf write to:
+// os.Stdout for the root gawk Builder, os.Stderr for gawk.Stderr, or
+// whatever was passed to WithWriter for a Builder created with New.
+func (builder *Builder) defaultWriter() io.Writer {
+	if builder.config != nil && builder.config.Writer != nil {
+		return builder.config.Writer
+	}
+	return os.Stdout
+}
+
+// Print applies builder's style to its arguments, formatted as fmt.Print
+// would, and writes the result to builder's default writer.
+func (builder *Builder) Print(a ...interface{}) (int, error) {
+	return fmt.Fprint(builder.Writer(builder.defaultWriter()), a...)
+}
+
+// Println is like Print, but appends a newline.
+func (builder *Builder) Println(a ...interface{}) (int, error) {
+	return fmt.Fprintln(builder.Writer(builder.defaultWriter()), a...)
+}
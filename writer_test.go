@@ -0,0 +1,82 @@
+package gawk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBuilderWriterAppliesStyle(t *testing.T) {
+	var buf bytes.Buffer
+	builder := New(ForceLevel(LevelAnsi16m)).WithRed()
+
+	w := builder.Writer(&buf)
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	if got, want := buf.String(), builder.applyStyle("hello"); got != want {
+		t.Errorf("Writer output = %q, want %q", got, want)
+	}
+}
+
+func TestBuilderWriterMultiLine(t *testing.T) {
+	var buf bytes.Buffer
+	builder := New(ForceLevel(LevelAnsi16m)).WithRed()
+
+	if _, err := builder.Writer(&buf).Write([]byte("line1\nline2")); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	// Writer must go through the same CRLF handling as applyStyle, closing
+	// the style before each line break and reopening it after.
+	if got, want := buf.String(), builder.applyStyle("line1\nline2"); got != want {
+		t.Errorf("Writer multi-line output = %q, want %q", got, want)
+	}
+	if !strings.Contains(buf.String(), "\n") {
+		t.Fatalf("Writer output = %q, want it to still contain the newline", buf.String())
+	}
+}
+
+func TestBuilderPrintWritesStyledOutput(t *testing.T) {
+	var buf bytes.Buffer
+	builder := New(ForceLevel(LevelAnsi16m), WithWriter(&buf)).WithGreen()
+
+	if _, err := builder.Print("hello", "world"); err != nil {
+		t.Fatalf("Print returned unexpected error: %v", err)
+	}
+
+	if got, want := buf.String(), builder.applyStyle("helloworld"); got != want {
+		t.Errorf("Print output = %q, want %q", got, want)
+	}
+}
+
+func TestBuilderPrintlnWritesStyledOutputWithNewline(t *testing.T) {
+	var buf bytes.Buffer
+	builder := New(ForceLevel(LevelAnsi16m), WithWriter(&buf)).WithGreen()
+
+	if _, err := builder.Println("hello", "world"); err != nil {
+		t.Fatalf("Println returned unexpected error: %v", err)
+	}
+
+	if got, want := buf.String(), builder.applyStyle("hello world\n"); got != want {
+		t.Errorf("Println output = %q, want %q", got, want)
+	}
+}
+
+func TestBuilderPrintUsesConfiguredDefaultWriter(t *testing.T) {
+	var stdoutBuf, stderrBuf bytes.Buffer
+
+	stdout := New(ForceLevel(LevelAnsi16m), WithWriter(&stdoutBuf))
+	stderr := New(ForceLevel(LevelAnsi16m), WithWriter(&stderrBuf))
+
+	stdout.Print("out")
+	stderr.Print("err")
+
+	if stdoutBuf.String() == "" || stderrBuf.String() == "" {
+		t.Fatal("Print did not write to the Builder's configured default writer")
+	}
+	if stdoutBuf.String() == stderrBuf.String() {
+		t.Errorf("stdout and stderr builders produced the same output: %q", stdoutBuf.String())
+	}
+}